@@ -0,0 +1,85 @@
+package deepmerge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Auto merges two documents (dst and src) into one, automatically detecting whether each document is JSON or YAML
+// and merging them with the same semantics as YAML/JSON. The output is returned in the format that was detected.
+//
+// If dst and src are in different formats, the format of dst is used for the output, so e.g. a JSON base
+// can be overlaid with a YAML fragment.
+func Auto(dst, src []byte, config Config) ([]byte, error) {
+	dstValue, dstIsJSON, err := unmarshalDocument(dst)
+	if err != nil {
+		return nil, err
+	}
+	srcValue, _, err := unmarshalDocument(src)
+	if err != nil {
+		return nil, err
+	}
+	dstMap, err := asMap(dstValue)
+	if err != nil {
+		return nil, err
+	}
+	srcMap, err := asMap(srcValue)
+	if err != nil {
+		return nil, err
+	}
+	output, err := merge(dstMap, srcMap, config, "")
+	if err != nil {
+		return nil, err
+	}
+	return marshalDocument(output, dstIsJSON)
+}
+
+// asMap asserts that value is a map[string]interface{}, treating a nil value (e.g. an empty document) as
+// an empty map
+func asMap(value interface{}) (map[string]interface{}, error) {
+	if value == nil {
+		return map[string]interface{}{}, nil
+	}
+	valueAsMap, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("deepmerge: Auto only supports merging documents whose root is an object, got %T", value)
+	}
+	return valueAsMap, nil
+}
+
+// isJSON returns whether data looks like a JSON document, i.e. whether it is valid JSON whose first
+// non-whitespace token is an object or an array.
+func isJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return false
+	}
+	return json.Valid(trimmed)
+}
+
+// unmarshalDocument unmarshals data into a generic interface{}, automatically detecting whether it is JSON
+// or YAML, and reports which format was detected
+func unmarshalDocument(data []byte) (interface{}, bool, error) {
+	var value interface{}
+	if isJSON(data) {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, true, err
+		}
+		return value, true, nil
+	}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, false, err
+	}
+	return value, false, nil
+}
+
+// marshalDocument marshals value as JSON if asJSON is true, or as YAML otherwise
+func marshalDocument(value interface{}, asJSON bool) ([]byte, error) {
+	if asJSON {
+		return json.Marshal(value)
+	}
+	return yaml.Marshal(value)
+}
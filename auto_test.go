@@ -0,0 +1,54 @@
+package deepmerge_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TwiN/deepmerge"
+)
+
+func TestAuto(t *testing.T) {
+	scenarios := []struct {
+		name string
+		dst  string
+		src  string
+	}{
+		{
+			name: "json",
+			dst:  `{"debug":true}`,
+			src:  `{"metrics":true}`,
+		},
+		{
+			name: "yaml",
+			dst:  "debug: true",
+			src:  "metrics: true",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			output, err := deepmerge.Auto([]byte(scenario.dst), []byte(scenario.src), deepmerge.Config{})
+			if err != nil {
+				t.Fatalf("[%s] didn't expect an error, got %v", scenario.name, err)
+			}
+			if len(output) == 0 {
+				t.Errorf("[%s] expected non-empty output", scenario.name)
+			}
+		})
+	}
+}
+
+func TestAuto_WithMixedFormats(t *testing.T) {
+	// dst is JSON (e.g. an OpenAPI spec) overlaid with a YAML fragment; output should be JSON, since that's
+	// the format of dst.
+	output, err := deepmerge.Auto([]byte(`{"debug":true}`), []byte("metrics: true\n"), deepmerge.Config{})
+	if err != nil {
+		t.Fatalf("didn't expect an error, got %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("expected output to be valid JSON, got %s: %v", output, err)
+	}
+	if result["debug"] != true || result["metrics"] != true {
+		t.Errorf("expected debug and metrics to both be true, got %v", result)
+	}
+}
@@ -0,0 +1,102 @@
+package deepmerge
+
+import (
+	"errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrKeyWithPrimitiveValueDefinedMoreThanOnce is the error returned when a key whose value is a primitive
+	// (i.e. not a map or a slice) is defined in both dst and src, and
+	// Config.PreventMultipleDefinitionsOfKeysWithPrimitiveValue is set to true
+	ErrKeyWithPrimitiveValueDefinedMoreThanOnce = errors.New("key with primitive value is defined more than once")
+)
+
+// Config is the configuration for merging two documents
+type Config struct {
+	// PreventMultipleDefinitionsOfKeysWithPrimitiveValue specifies whether to prevent keys with a primitive value
+	// (i.e. not a map or a slice) from being defined in both dst and src.
+	//
+	// If set to true and such a key is defined in both documents, ErrKeyWithPrimitiveValueDefinedMoreThanOnce is
+	// returned by YAML.
+	//
+	// Defaults to false
+	PreventMultipleDefinitionsOfKeysWithPrimitiveValue bool
+
+	// Strategies specifies, for a given dotted key path (e.g. "endpoints" or "endpoints.*.conditions"), which
+	// MergeStrategy to use when merging the slice found at that path, instead of the default of appending src
+	// after dst. A "*" path segment matches any key or slice index at that position.
+	//
+	// Defaults to nil, meaning every slice is merged using Append()
+	Strategies map[string]MergeStrategy
+
+	// DocumentKey is the list of dotted key paths (e.g. []string{"apiVersion", "kind", "metadata.name"})
+	// whose values, taken together, identify a document when merging multi-document YAML streams with
+	// YAMLDocuments.
+	DocumentKey []string
+}
+
+// YAML merges two YAML documents (dst and src) into one, with src taking precedence over dst in the event
+// that there's a conflict, and returns the result.
+//
+// The precedence mentioned above only applies to values that are not maps or slices, as maps are merged
+// recursively and slices are appended to rather than replaced.
+//
+// Anchors, aliases and "<<" merge keys in dst and src are resolved by the underlying YAML decoder before
+// either document reaches merge, so they never need special handling here.
+func YAML(dst, src []byte, config Config) ([]byte, error) {
+	var dstMap, srcMap map[string]interface{}
+	if err := yaml.Unmarshal(dst, &dstMap); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(src, &srcMap); err != nil {
+		return nil, err
+	}
+	output, err := merge(dstMap, srcMap, config, "")
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(output)
+}
+
+// merge recursively merges src into dst and returns the result. path is the dotted key path of dst/src
+// within the overall document, and is used to look up a per-key MergeStrategy in config.Strategies.
+func merge(dst, src map[string]interface{}, config Config, path string) (map[string]interface{}, error) {
+	if dst == nil {
+		dst = make(map[string]interface{})
+	}
+	for key, srcValue := range src {
+		childPath := joinPath(path, key)
+		dstValue, keyExistsInDst := dst[key]
+		if !keyExistsInDst {
+			dst[key] = srcValue
+			continue
+		}
+		srcValueAsMap, srcValueIsMap := srcValue.(map[string]interface{})
+		dstValueAsMap, dstValueIsMap := dstValue.(map[string]interface{})
+		if srcValueIsMap && dstValueIsMap {
+			merged, err := merge(dstValueAsMap, srcValueAsMap, config, childPath)
+			if err != nil {
+				return nil, err
+			}
+			dst[key] = merged
+			continue
+		}
+		srcValueAsSlice, srcValueIsSlice := srcValue.([]interface{})
+		dstValueAsSlice, dstValueIsSlice := dstValue.([]interface{})
+		if srcValueIsSlice && dstValueIsSlice {
+			merged, err := mergeSlices(childPath, dstValueAsSlice, srcValueAsSlice, config)
+			if err != nil {
+				return nil, err
+			}
+			dst[key] = merged
+			continue
+		}
+		if config.PreventMultipleDefinitionsOfKeysWithPrimitiveValue {
+			return nil, ErrKeyWithPrimitiveValueDefinedMoreThanOnce
+		}
+		dst[key] = srcValue
+	}
+	return dst, nil
+}
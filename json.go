@@ -0,0 +1,23 @@
+package deepmerge
+
+import "encoding/json"
+
+// JSON merges two JSON documents (dst and src) into one, with src taking precedence over dst in the event
+// that there's a conflict, and returns the result.
+//
+// The precedence mentioned above only applies to values that are not objects or arrays, as objects are merged
+// recursively and arrays are appended to rather than replaced. This mirrors the semantics of YAML.
+func JSON(dst, src []byte, config Config) ([]byte, error) {
+	var dstMap, srcMap map[string]interface{}
+	if err := json.Unmarshal(dst, &dstMap); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(src, &srcMap); err != nil {
+		return nil, err
+	}
+	output, err := merge(dstMap, srcMap, config, "")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(output)
+}
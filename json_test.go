@@ -0,0 +1,74 @@
+package deepmerge_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/TwiN/deepmerge"
+)
+
+func TestJSON(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      deepmerge.Config
+		dst         string
+		src         string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:        "invalid-dst",
+			dst:         `not-json`,
+			src:         `{}`,
+			expectedErr: errors.New("invalid character 'o' in literal null (expecting 'u')"),
+		},
+		{
+			name:     "simple-endpoint-merge",
+			dst:      `{"endpoints":[{"name":"one","url":"https://example.com"}]}`,
+			src:      `{"endpoints":[{"name":"two","url":"https://example.org"}]}`,
+			expected: `{"endpoints":[{"name":"one","url":"https://example.com"},{"name":"two","url":"https://example.org"}]}`,
+		},
+		{
+			name:     "deep-merge-with-map-and-primitive",
+			dst:      `{"metrics":true,"alerting":{"slack":{"webhook-url":"https://hooks.slack.com"}}}`,
+			src:      `{"debug":true,"alerting":{"discord":{"webhook-url":"https://discord.com"}}}`,
+			expected: `{"metrics":true,"debug":true,"alerting":{"slack":{"webhook-url":"https://hooks.slack.com"},"discord":{"webhook-url":"https://discord.com"}}}`,
+		},
+		{
+			name:        "duplicate-key-with-primitive-value",
+			config:      deepmerge.Config{PreventMultipleDefinitionsOfKeysWithPrimitiveValue: true},
+			dst:         `{"metrics":true}`,
+			src:         `{"metrics":false}`,
+			expectedErr: deepmerge.ErrKeyWithPrimitiveValueDefinedMoreThanOnce,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			output, err := deepmerge.JSON([]byte(scenario.dst), []byte(scenario.src), scenario.config)
+			if !errors.Is(err, scenario.expectedErr) && !(scenario.expectedErr != nil && err.Error() == scenario.expectedErr.Error()) {
+				t.Errorf("[%s] expected error %v, got %v", scenario.name, scenario.expectedErr, err)
+			}
+			expectedAsMap, outputAsMap := make(map[string]interface{}), make(map[string]interface{})
+			if len(output) > 0 {
+				if err := json.Unmarshal(output, &outputAsMap); err != nil {
+					t.Errorf("[%s] failed to unmarshal output: %v", scenario.name, err)
+				}
+			}
+			if len(scenario.expected) > 0 {
+				if err := json.Unmarshal([]byte(scenario.expected), &expectedAsMap); err != nil {
+					t.Errorf("[%s] failed to unmarshal expected: %v", scenario.name, err)
+				}
+			}
+			if len(scenario.expected) > 0 && !mapsEqual(outputAsMap, expectedAsMap) {
+				t.Errorf("[%s] expected:\n%v\n\ngot:\n%v", scenario.name, expectedAsMap, outputAsMap)
+			}
+		})
+	}
+}
+
+func mapsEqual(a, b map[string]interface{}) bool {
+	aBytes, _ := json.Marshal(a)
+	bBytes, _ := json.Marshal(b)
+	return string(aBytes) == string(bBytes)
+}
@@ -0,0 +1,344 @@
+package deepmerge
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ApplyMergePatch applies a RFC 7396 JSON Merge Patch to doc and returns the result.
+//
+// doc and patch may each be JSON or YAML; the format of each is detected independently, and the output is
+// returned in the format detected for doc. Within patch, a null value deletes the corresponding key from
+// doc, and any other non-object value replaces it outright.
+func ApplyMergePatch(doc, patch []byte) ([]byte, error) {
+	docValue, docIsJSON, err := unmarshalDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	patchValue, _, err := unmarshalDocument(patch)
+	if err != nil {
+		return nil, err
+	}
+	return marshalDocument(mergePatch(docValue, patchValue), docIsJSON)
+}
+
+// mergePatch implements the recursive merge algorithm described by RFC 7396
+func mergePatch(target, patch interface{}) interface{} {
+	patchAsMap, patchIsMap := patch.(map[string]interface{})
+	if !patchIsMap {
+		return patch
+	}
+	targetAsMap, targetIsMap := target.(map[string]interface{})
+	if !targetIsMap {
+		targetAsMap = map[string]interface{}{}
+	}
+	result := make(map[string]interface{}, len(targetAsMap))
+	for key, value := range targetAsMap {
+		result[key] = value
+	}
+	for key, patchValue := range patchAsMap {
+		if patchValue == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = mergePatch(result[key], patchValue)
+	}
+	return result
+}
+
+// jsonPatchOperation is a single operation of a RFC 6902 JSON Patch
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ApplyJSONPatch applies a RFC 6902 JSON Patch to doc and returns the result.
+//
+// doc may be JSON or YAML; the output is returned in the format detected for doc. patch is always a JSON
+// array of operations, as defined by RFC 6902. Paths are JSON Pointers as defined by RFC 6901.
+func ApplyJSONPatch(doc, patch []byte) ([]byte, error) {
+	docValue, docIsJSON, err := unmarshalDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	var operations []jsonPatchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, err
+	}
+	root := docValue
+	for _, operation := range operations {
+		root, err = applyJSONPatchOperation(root, operation)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return marshalDocument(root, docIsJSON)
+}
+
+func applyJSONPatchOperation(root interface{}, operation jsonPatchOperation) (interface{}, error) {
+	tokens, err := parseJSONPointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch operation.Op {
+	case "add":
+		return addAtPointer(root, tokens, operation.Value)
+	case "remove":
+		newRoot, _, err := removeAtPointer(root, tokens)
+		return newRoot, err
+	case "replace":
+		if _, err := getAtPointer(root, tokens); err != nil {
+			return nil, err
+		}
+		return setAtPointer(root, tokens, operation.Value)
+	case "move":
+		fromTokens, err := parseJSONPointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		newRoot, value, err := removeAtPointer(root, fromTokens)
+		if err != nil {
+			return nil, err
+		}
+		return addAtPointer(newRoot, tokens, value)
+	case "copy":
+		fromTokens, err := parseJSONPointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getAtPointer(root, fromTokens)
+		if err != nil {
+			return nil, err
+		}
+		return addAtPointer(root, tokens, deepCopyValue(value))
+	case "test":
+		value, err := getAtPointer(root, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if !valuesEqual(value, operation.Value) {
+			return nil, fmt.Errorf("test operation failed at path %q", operation.Path)
+		}
+		return root, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON Patch operation: %q", operation.Op)
+	}
+}
+
+// parseJSONPointer splits a RFC 6901 JSON Pointer into its unescaped reference tokens
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer: %q", pointer)
+	}
+	rawTokens := strings.Split(pointer[1:], "/")
+	tokens := make([]string, len(rawTokens))
+	for i, token := range rawTokens {
+		token = strings.ReplaceAll(token, "~1", "/")
+		token = strings.ReplaceAll(token, "~0", "~")
+		tokens[i] = token
+	}
+	return tokens, nil
+}
+
+// getAtPointer resolves tokens against root and returns the value found there
+func getAtPointer(root interface{}, tokens []string) (interface{}, error) {
+	current := root
+	for _, token := range tokens {
+		switch typed := current.(type) {
+		case map[string]interface{}:
+			value, ok := typed[token]
+			if !ok {
+				return nil, fmt.Errorf("path not found: %q", token)
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(typed, token, false)
+			if err != nil {
+				return nil, err
+			}
+			current = typed[index]
+		default:
+			return nil, fmt.Errorf("cannot navigate into a non-container value at %q", token)
+		}
+	}
+	return current, nil
+}
+
+// setAtPointer sets the value found at tokens to value and returns the (possibly new) root
+func setAtPointer(root interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	parent, err := getAtPointer(root, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+	lastToken := tokens[len(tokens)-1]
+	switch typed := parent.(type) {
+	case map[string]interface{}:
+		typed[lastToken] = value
+		return root, nil
+	case []interface{}:
+		index, err := arrayIndex(typed, lastToken, false)
+		if err != nil {
+			return nil, err
+		}
+		typed[index] = value
+		return root, nil
+	default:
+		return nil, fmt.Errorf("cannot set value inside a non-container at %q", lastToken)
+	}
+}
+
+// addAtPointer adds value at tokens, inserting into an array (or appending, for the "-" token) rather than
+// replacing when the target is an array, and returns the (possibly new) root
+func addAtPointer(root interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	parentTokens := tokens[:len(tokens)-1]
+	lastToken := tokens[len(tokens)-1]
+	parent, err := getAtPointer(root, parentTokens)
+	if err != nil {
+		return nil, err
+	}
+	switch typed := parent.(type) {
+	case map[string]interface{}:
+		typed[lastToken] = value
+		return root, nil
+	case []interface{}:
+		index, err := arrayIndex(typed, lastToken, true)
+		if err != nil {
+			return nil, err
+		}
+		newSlice := make([]interface{}, 0, len(typed)+1)
+		newSlice = append(newSlice, typed[:index]...)
+		newSlice = append(newSlice, value)
+		newSlice = append(newSlice, typed[index:]...)
+		return setAtPointer(root, parentTokens, newSlice)
+	default:
+		return nil, fmt.Errorf("cannot add value inside a non-container at %q", lastToken)
+	}
+}
+
+// removeAtPointer removes the value found at tokens and returns the (possibly new) root along with the
+// value that was removed
+func removeAtPointer(root interface{}, tokens []string) (interface{}, interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the document root")
+	}
+	parentTokens := tokens[:len(tokens)-1]
+	lastToken := tokens[len(tokens)-1]
+	parent, err := getAtPointer(root, parentTokens)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch typed := parent.(type) {
+	case map[string]interface{}:
+		value, ok := typed[lastToken]
+		if !ok {
+			return nil, nil, fmt.Errorf("path not found: %q", lastToken)
+		}
+		delete(typed, lastToken)
+		return root, value, nil
+	case []interface{}:
+		index, err := arrayIndex(typed, lastToken, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		removed := typed[index]
+		newSlice := make([]interface{}, 0, len(typed)-1)
+		newSlice = append(newSlice, typed[:index]...)
+		newSlice = append(newSlice, typed[index+1:]...)
+		newRoot, err := setAtPointer(root, parentTokens, newSlice)
+		return newRoot, removed, err
+	default:
+		return nil, nil, fmt.Errorf("cannot remove value inside a non-container at %q", lastToken)
+	}
+}
+
+// arrayIndex resolves a JSON Pointer reference token against arr, accepting "-" (meaning "one past the
+// end") only when forInsert is true
+func arrayIndex(arr []interface{}, token string, forInsert bool) (int, error) {
+	if token == "-" {
+		if forInsert {
+			return len(arr), nil
+		}
+		return -1, fmt.Errorf("array index '-' is not valid here")
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return -1, fmt.Errorf("invalid array index: %q", token)
+	}
+	maxIndex := len(arr)
+	if !forInsert {
+		maxIndex = len(arr) - 1
+	}
+	if index < 0 || index > maxIndex {
+		return -1, fmt.Errorf("array index out of bounds: %q", token)
+	}
+	return index, nil
+}
+
+// valuesEqual reports whether a and b are deeply equal once numbers are normalized to a common
+// representation. This matters because operation.Value is always decoded by encoding/json (numbers become
+// float64), while value may have come from a YAML document (where yaml.v3 decodes integers as int/int64).
+func valuesEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(normalizeNumbers(a), normalizeNumbers(b))
+}
+
+// normalizeNumbers recursively converts int/int64/uint64 values to float64, so documents decoded by
+// encoding/json and gopkg.in/yaml.v3 can be compared for deep equality regardless of their source
+func normalizeNumbers(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(typed))
+		for key, v := range typed {
+			normalized[key] = normalizeNumbers(v)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(typed))
+		for i, v := range typed {
+			normalized[i] = normalizeNumbers(v)
+		}
+		return normalized
+	case int:
+		return float64(typed)
+	case int64:
+		return float64(typed)
+	case uint64:
+		return float64(typed)
+	default:
+		return value
+	}
+}
+
+// deepCopyValue returns a deep copy of value, which is expected to be composed of the types produced by
+// json.Unmarshal/yaml.Unmarshal into interface{} (map[string]interface{}, []interface{} and primitives)
+func deepCopyValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(typed))
+		for key, v := range typed {
+			copied[key] = deepCopyValue(v)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(typed))
+		for i, v := range typed {
+			copied[i] = deepCopyValue(v)
+		}
+		return copied
+	default:
+		return value
+	}
+}
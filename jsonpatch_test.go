@@ -0,0 +1,146 @@
+package deepmerge_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TwiN/deepmerge"
+	"gopkg.in/yaml.v3"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "replace-and-delete",
+			doc:      `{"a":"b","c":{"d":"e","f":"g"}}`,
+			patch:    `{"a":"z","c":{"f":null}}`,
+			expected: `{"a":"z","c":{"d":"e"}}`,
+		},
+		{
+			name:     "array-is-replaced-outright",
+			doc:      `{"a":["b"]}`,
+			patch:    `{"a":"c"}`,
+			expected: `{"a":"c"}`,
+		},
+		{
+			name:     "works-on-yaml",
+			doc:      "a: b\nc:\n  d: e\n  f: g\n",
+			patch:    `{"a":"z","c":{"f":null}}`,
+			expected: "a: z\nc:\n  d: e\n",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			output, err := deepmerge.ApplyMergePatch([]byte(scenario.doc), []byte(scenario.patch))
+			if err != nil {
+				t.Fatalf("[%s] didn't expect an error, got %v", scenario.name, err)
+			}
+			if !jsonEquivalent(t, scenario.name, output, []byte(scenario.expected)) {
+				t.Errorf("[%s] expected %s, got %s", scenario.name, scenario.expected, output)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "add-to-object",
+			doc:      `{"a":"b"}`,
+			patch:    `[{"op":"add","path":"/c","value":"d"}]`,
+			expected: `{"a":"b","c":"d"}`,
+		},
+		{
+			name:     "add-to-array-end",
+			doc:      `{"a":["b","c"]}`,
+			patch:    `[{"op":"add","path":"/a/-","value":"d"}]`,
+			expected: `{"a":["b","c","d"]}`,
+		},
+		{
+			name:     "remove-from-object",
+			doc:      `{"a":"b","c":"d"}`,
+			patch:    `[{"op":"remove","path":"/a"}]`,
+			expected: `{"c":"d"}`,
+		},
+		{
+			name:     "replace",
+			doc:      `{"a":"b"}`,
+			patch:    `[{"op":"replace","path":"/a","value":"c"}]`,
+			expected: `{"a":"c"}`,
+		},
+		{
+			name:     "move",
+			doc:      `{"a":{"b":"c"},"d":{}}`,
+			patch:    `[{"op":"move","from":"/a/b","path":"/d/b"}]`,
+			expected: `{"a":{},"d":{"b":"c"}}`,
+		},
+		{
+			name:     "copy",
+			doc:      `{"a":{"b":"c"},"d":{}}`,
+			patch:    `[{"op":"copy","from":"/a/b","path":"/d/b"}]`,
+			expected: `{"a":{"b":"c"},"d":{"b":"c"}}`,
+		},
+		{
+			name:     "test-passes",
+			doc:      `{"a":"b"}`,
+			patch:    `[{"op":"test","path":"/a","value":"b"},{"op":"add","path":"/c","value":"d"}]`,
+			expected: `{"a":"b","c":"d"}`,
+		},
+		{
+			name:    "test-fails",
+			doc:     `{"a":"b"}`,
+			patch:   `[{"op":"test","path":"/a","value":"z"}]`,
+			wantErr: true,
+		},
+		{
+			name:     "test-passes-with-numeric-value-on-yaml-doc",
+			doc:      "count: 5\n",
+			patch:    `[{"op":"test","path":"/count","value":5},{"op":"replace","path":"/count","value":6}]`,
+			expected: `{"count":6}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			output, err := deepmerge.ApplyJSONPatch([]byte(scenario.doc), []byte(scenario.patch))
+			if scenario.wantErr {
+				if err == nil {
+					t.Errorf("[%s] expected an error, got none", scenario.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("[%s] didn't expect an error, got %v", scenario.name, err)
+			}
+			if !jsonEquivalent(t, scenario.name, output, []byte(scenario.expected)) {
+				t.Errorf("[%s] expected %s, got %s", scenario.name, scenario.expected, output)
+			}
+		})
+	}
+}
+
+// jsonEquivalent compares output and expected for deep equality regardless of whether they're encoded as
+// JSON or YAML
+func jsonEquivalent(t *testing.T, name string, output, expected []byte) bool {
+	t.Helper()
+	var outputValue, expectedValue interface{}
+	if err := yaml.Unmarshal(output, &outputValue); err != nil {
+		t.Fatalf("[%s] failed to unmarshal output: %v", name, err)
+	}
+	if err := yaml.Unmarshal(expected, &expectedValue); err != nil {
+		t.Fatalf("[%s] failed to unmarshal expected: %v", name, err)
+	}
+	outputBytes, _ := json.Marshal(outputValue)
+	expectedBytes, _ := json.Marshal(expectedValue)
+	return string(outputBytes) == string(expectedBytes)
+}
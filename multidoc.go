@@ -0,0 +1,122 @@
+package deepmerge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLDocuments merges two streams of "---"-separated YAML documents (dst and src) into one stream.
+//
+// Documents are paired up using config.DocumentKey, a list of dotted key paths (e.g.
+// []string{"apiVersion", "kind", "metadata.name"} for Kubernetes manifests) whose values, taken together,
+// identify a document: a src document is merged into the dst document sharing the same key values, using
+// the same semantics as YAML. Documents on either side whose key has no match on the other side are passed
+// through unmodified, in their original stream order, with dst documents first.
+func YAMLDocuments(dst, src []byte, config Config) ([]byte, error) {
+	if len(config.DocumentKey) == 0 {
+		return nil, errors.New("deepmerge: YAMLDocuments requires Config.DocumentKey to be set")
+	}
+	dstDocuments, err := decodeYAMLDocuments(dst)
+	if err != nil {
+		return nil, err
+	}
+	srcDocuments, err := decodeYAMLDocuments(src)
+	if err != nil {
+		return nil, err
+	}
+	srcIndicesByKey := make(map[string][]int, len(srcDocuments))
+	for i, document := range srcDocuments {
+		key := documentKey(document, config.DocumentKey)
+		srcIndicesByKey[key] = append(srcIndicesByKey[key], i)
+	}
+	srcConsumed := make([]bool, len(srcDocuments))
+	output := make([]map[string]interface{}, 0, len(dstDocuments)+len(srcDocuments))
+	for _, document := range dstDocuments {
+		key := documentKey(document, config.DocumentKey)
+		matchedIndex := -1
+		for _, index := range srcIndicesByKey[key] {
+			if !srcConsumed[index] {
+				matchedIndex = index
+				break
+			}
+		}
+		if matchedIndex >= 0 {
+			merged, err := merge(document, srcDocuments[matchedIndex], config, "")
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, merged)
+			srcConsumed[matchedIndex] = true
+			continue
+		}
+		output = append(output, document)
+	}
+	for i, document := range srcDocuments {
+		if !srcConsumed[i] {
+			output = append(output, document)
+		}
+	}
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	for _, document := range output {
+		if err := encoder.Encode(document); err != nil {
+			return nil, err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// decodeYAMLDocuments decodes a stream of "---"-separated YAML documents into a slice of maps
+func decodeYAMLDocuments(data []byte) ([]map[string]interface{}, error) {
+	var documents []map[string]interface{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document map[string]interface{}
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if document == nil {
+			continue
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// documentKey builds a composite key for document out of the dotted key paths in fields
+func documentKey(document map[string]interface{}, fields []string) string {
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = stringifyPathValue(document, field)
+	}
+	return strings.Join(values, "\x00")
+}
+
+// stringifyPathValue resolves a dotted key path (e.g. "metadata.name") against document and returns its
+// value formatted as a string, or "" if any segment of the path is missing
+func stringifyPathValue(document map[string]interface{}, path string) string {
+	var current interface{} = document
+	for _, segment := range strings.Split(path, ".") {
+		currentAsMap, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value, ok := currentAsMap[segment]
+		if !ok {
+			return ""
+		}
+		current = value
+	}
+	return fmt.Sprintf("%v", current)
+}
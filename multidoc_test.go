@@ -0,0 +1,91 @@
+package deepmerge_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TwiN/deepmerge"
+)
+
+func TestYAMLDocuments(t *testing.T) {
+	dst := `apiVersion: v1
+kind: Deployment
+metadata:
+  name: frontend
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  replicas: 1
+`
+	src := `apiVersion: v1
+kind: Deployment
+metadata:
+  name: frontend
+spec:
+  replicas: 3
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: frontend
+spec:
+  port: 80
+`
+	config := deepmerge.Config{DocumentKey: []string{"apiVersion", "kind", "metadata.name"}}
+	output, err := deepmerge.YAMLDocuments([]byte(dst), []byte(src), config)
+	if err != nil {
+		t.Fatalf("didn't expect an error, got %v", err)
+	}
+	documents := strings.Split(strings.TrimSpace(string(output)), "---")
+	if len(documents) != 3 {
+		t.Fatalf("expected 3 documents, got %d:\n%s", len(documents), output)
+	}
+	if !strings.Contains(documents[0], "replicas: 3") {
+		t.Errorf("expected the frontend deployment to be merged with replicas: 3, got:\n%s", documents[0])
+	}
+	if !strings.Contains(documents[1], "backend") {
+		t.Errorf("expected the backend deployment to be passed through, got:\n%s", documents[1])
+	}
+	if !strings.Contains(documents[2], "Service") {
+		t.Errorf("expected the frontend service to be passed through, got:\n%s", documents[2])
+	}
+}
+
+func TestYAMLDocuments_RequiresDocumentKey(t *testing.T) {
+	_, err := deepmerge.YAMLDocuments([]byte("a: 1"), []byte("b: 2"), deepmerge.Config{})
+	if err == nil {
+		t.Error("expected an error when Config.DocumentKey is not set")
+	}
+}
+
+func TestYAMLDocuments_DuplicateKeysAreMatchedInOrder(t *testing.T) {
+	dst := `name: a
+value: one
+---
+name: a
+value: two
+`
+	src := `name: a
+value: three
+`
+	config := deepmerge.Config{DocumentKey: []string{"name"}}
+	output, err := deepmerge.YAMLDocuments([]byte(dst), []byte(src), config)
+	if err != nil {
+		t.Fatalf("didn't expect an error, got %v", err)
+	}
+	documents := strings.Split(strings.TrimSpace(string(output)), "---")
+	if len(documents) != 2 {
+		t.Fatalf("expected 2 documents, got %d:\n%s", len(documents), output)
+	}
+	if !strings.Contains(documents[0], "value: three") {
+		t.Errorf("expected the first document to be merged with value: three, got:\n%s", documents[0])
+	}
+	if !strings.Contains(documents[1], "value: two") {
+		t.Errorf("expected the second document to be passed through unmodified, got:\n%s", documents[1])
+	}
+}
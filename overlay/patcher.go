@@ -0,0 +1,89 @@
+// Package overlay provides a way to patch a base configuration file with companion overlay files,
+// turning deepmerge into a drop-in replacement for os.ReadFile in applications that support
+// split/override configuration.
+package overlay
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/TwiN/deepmerge"
+)
+
+// Patcher discovers and merges the overlays for a base configuration file.
+//
+// Given a base file at Path, it looks for a sibling Path+Suffix file (e.g. config.yaml.local) and for
+// any files inside a Path+Suffix+".d" directory (e.g. config.yaml.local.d/), merging all of them into
+// the base file's content, in that order.
+type Patcher struct {
+	// Path is the path to the base configuration file
+	Path string
+
+	// Suffix is appended to Path to build the overlay file and overlay directory paths
+	Suffix string
+
+	// Config is passed through to deepmerge.YAML/deepmerge.JSON when merging the base file with its overlays
+	Config deepmerge.Config
+}
+
+// NewPatcher creates a Patcher for the base configuration file at path, using suffix to derive the paths
+// of its overlay file and overlay directory.
+func NewPatcher(path, suffix string) *Patcher {
+	return &Patcher{Path: path, Suffix: suffix}
+}
+
+// MergedPatchContent reads the base configuration file and merges it with its overlay file, if present,
+// and with every file inside its overlay directory, if present, sorted lexicographically by file name.
+//
+// The format used to merge (JSON or YAML) is determined by the extension of Path.
+func (p *Patcher) MergedPatchContent() ([]byte, error) {
+	content, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	merge := p.mergeFunc()
+	localPath := p.Path + p.Suffix
+	localContent, err := os.ReadFile(localPath)
+	if err == nil {
+		if content, err = merge(content, localContent, p.Config); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	dirPath := p.Path + p.Suffix + ".d"
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return content, nil
+		}
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fragment, err := os.ReadFile(filepath.Join(dirPath, name))
+		if err != nil {
+			return nil, err
+		}
+		if content, err = merge(content, fragment, p.Config); err != nil {
+			return nil, err
+		}
+	}
+	return content, nil
+}
+
+// mergeFunc returns deepmerge.JSON if Path has a .json extension, or deepmerge.YAML otherwise
+func (p *Patcher) mergeFunc() func(dst, src []byte, config deepmerge.Config) ([]byte, error) {
+	if strings.EqualFold(filepath.Ext(p.Path), ".json") {
+		return deepmerge.JSON
+	}
+	return deepmerge.YAML
+}
@@ -0,0 +1,64 @@
+package overlay_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TwiN/deepmerge/overlay"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPatcher_MergedPatchContent(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "config.yaml")
+	writeFile(t, basePath, "debug: true\nendpoints:\n  - name: one\n")
+	writeFile(t, basePath+".local", "metrics: true\n")
+	if err := os.Mkdir(basePath+".local.d", 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(basePath+".local.d", "01-endpoints.yaml"), "endpoints:\n  - name: two\n")
+
+	patcher := overlay.NewPatcher(basePath, ".local")
+	output, err := patcher.MergedPatchContent()
+	if err != nil {
+		t.Fatalf("didn't expect an error, got %v", err)
+	}
+	var result map[string]interface{}
+	if err := yaml.Unmarshal(output, &result); err != nil {
+		t.Fatalf("failed to unmarshal output: %v", err)
+	}
+	if result["debug"] != true || result["metrics"] != true {
+		t.Errorf("expected debug and metrics to be true, got %v", result)
+	}
+	endpoints, ok := result["endpoints"].([]interface{})
+	if !ok || len(endpoints) != 2 {
+		t.Errorf("expected 2 endpoints, got %v", result["endpoints"])
+	}
+}
+
+func TestPatcher_MergedPatchContent_NoOverlays(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "config.yaml")
+	writeFile(t, basePath, "debug: true\n")
+
+	patcher := overlay.NewPatcher(basePath, ".local")
+	output, err := patcher.MergedPatchContent()
+	if err != nil {
+		t.Fatalf("didn't expect an error, got %v", err)
+	}
+	var result map[string]interface{}
+	if err := yaml.Unmarshal(output, &result); err != nil {
+		t.Fatalf("failed to unmarshal output: %v", err)
+	}
+	if result["debug"] != true {
+		t.Errorf("expected debug to be true, got %v", result)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
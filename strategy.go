@@ -0,0 +1,187 @@
+package deepmerge
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MergeStrategyType identifies how a slice found at a given key path should be merged
+type MergeStrategyType string
+
+const (
+	MergeStrategyAppend     MergeStrategyType = "append"
+	MergeStrategyPrepend    MergeStrategyType = "prepend"
+	MergeStrategyReplace    MergeStrategyType = "replace"
+	MergeStrategyMergeByKey MergeStrategyType = "mergeByKey"
+	MergeStrategyUnique     MergeStrategyType = "unique"
+)
+
+// MergeStrategy describes how the slice found at a given key path in Config.Strategies should be merged
+type MergeStrategy struct {
+	// Type is the kind of merge to perform
+	Type MergeStrategyType
+
+	// Key is the field used to match slice elements together when Type is MergeStrategyMergeByKey
+	Key string
+}
+
+// Append returns a MergeStrategy that appends src after dst. This is the default strategy for slices.
+func Append() MergeStrategy {
+	return MergeStrategy{Type: MergeStrategyAppend}
+}
+
+// Prepend returns a MergeStrategy that appends src before dst
+func Prepend() MergeStrategy {
+	return MergeStrategy{Type: MergeStrategyPrepend}
+}
+
+// Replace returns a MergeStrategy that makes src replace dst outright
+func Replace() MergeStrategy {
+	return MergeStrategy{Type: MergeStrategyReplace}
+}
+
+// MergeByKey returns a MergeStrategy that, rather than concatenating dst and src, deep-merges elements
+// that share the same value for key, and appends elements from src whose key has no match in dst.
+//
+// Elements that aren't objects, or that don't have key, are treated as if they had no match and are
+// appended as-is.
+func MergeByKey(key string) MergeStrategy {
+	return MergeStrategy{Type: MergeStrategyMergeByKey, Key: key}
+}
+
+// Unique returns a MergeStrategy that appends src after dst and then removes duplicate elements
+func Unique() MergeStrategy {
+	return MergeStrategy{Type: MergeStrategyUnique}
+}
+
+// joinPath appends key to path, separating them with a "."
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// matchStrategy looks up the MergeStrategy registered in config.Strategies for path, supporting "*"
+// wildcard segments in the registered pattern.
+//
+// When more than one pattern matches, the one with the fewest "*" segments wins, as it is the most
+// specific; ties are broken by comparing the patterns lexicographically, so the result is deterministic
+// regardless of map iteration order.
+func matchStrategy(config Config, path string) (MergeStrategy, bool) {
+	if strategy, ok := config.Strategies[path]; ok {
+		return strategy, true
+	}
+	pathSegments := strings.Split(path, ".")
+	bestPattern := ""
+	bestStrategy := MergeStrategy{}
+	found := false
+	for pattern, strategy := range config.Strategies {
+		patternSegments := strings.Split(pattern, ".")
+		if len(patternSegments) != len(pathSegments) {
+			continue
+		}
+		matches := true
+		for i, patternSegment := range patternSegments {
+			if patternSegment != "*" && patternSegment != pathSegments[i] {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		if !found || isMoreSpecific(pattern, bestPattern) {
+			bestPattern, bestStrategy, found = pattern, strategy, true
+		}
+	}
+	return bestStrategy, found
+}
+
+// isMoreSpecific reports whether pattern should be preferred over other: fewer "*" segments wins, and
+// ties are broken lexicographically so the choice doesn't depend on map iteration order
+func isMoreSpecific(pattern, other string) bool {
+	patternWildcards := strings.Count(pattern, "*")
+	otherWildcards := strings.Count(other, "*")
+	if patternWildcards != otherWildcards {
+		return patternWildcards < otherWildcards
+	}
+	return pattern < other
+}
+
+// mergeSlices merges src into dst according to the MergeStrategy registered for path in config.Strategies,
+// defaulting to MergeStrategyAppend when none is registered
+func mergeSlices(path string, dst, src []interface{}, config Config) ([]interface{}, error) {
+	strategy, ok := matchStrategy(config, path)
+	if !ok {
+		strategy = Append()
+	}
+	switch strategy.Type {
+	case MergeStrategyReplace:
+		return src, nil
+	case MergeStrategyPrepend:
+		return append(append([]interface{}{}, src...), dst...), nil
+	case MergeStrategyUnique:
+		return uniqueSlice(append(append([]interface{}{}, dst...), src...)), nil
+	case MergeStrategyMergeByKey:
+		return mergeSliceByKey(path, dst, src, strategy.Key, config)
+	default:
+		return append(append([]interface{}{}, dst...), src...), nil
+	}
+}
+
+// mergeSliceByKey deep-merges elements of dst and src that share the same value for key, and appends
+// elements from src whose key has no match in dst
+//
+// Key values are compared by their string representation rather than as Go map keys, since the decoded
+// value of key may be a map or a slice, which would panic a map[interface{}]int on lookup.
+func mergeSliceByKey(path string, dst, src []interface{}, key string, config Config) ([]interface{}, error) {
+	result := append([]interface{}{}, dst...)
+	indexByKeyValue := make(map[string]int, len(dst))
+	for i, item := range dst {
+		if itemAsMap, ok := item.(map[string]interface{}); ok {
+			if keyValue, ok := itemAsMap[key]; ok {
+				indexByKeyValue[fmt.Sprintf("%v", keyValue)] = i
+			}
+		}
+	}
+	childPath := joinPath(path, "*")
+	for _, srcItem := range src {
+		srcItemAsMap, srcItemIsMap := srcItem.(map[string]interface{})
+		if srcItemIsMap {
+			if keyValue, ok := srcItemAsMap[key]; ok {
+				if index, found := indexByKeyValue[fmt.Sprintf("%v", keyValue)]; found {
+					if dstItemAsMap, ok := result[index].(map[string]interface{}); ok {
+						merged, err := merge(dstItemAsMap, srcItemAsMap, config, childPath)
+						if err != nil {
+							return nil, err
+						}
+						result[index] = merged
+						continue
+					}
+				}
+			}
+		}
+		result = append(result, srcItem)
+	}
+	return result, nil
+}
+
+// uniqueSlice returns items with duplicate elements removed, keeping the first occurrence of each
+func uniqueSlice(items []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		isDuplicate := false
+		for _, existing := range result {
+			if reflect.DeepEqual(existing, item) {
+				isDuplicate = true
+				break
+			}
+		}
+		if !isDuplicate {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,152 @@
+package deepmerge_test
+
+import (
+	"testing"
+
+	"github.com/TwiN/deepmerge"
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAML_WithStrategies(t *testing.T) {
+	scenarios := []struct {
+		name       string
+		strategies map[string]deepmerge.MergeStrategy
+		dst        string
+		src        string
+		expected   string
+	}{
+		{
+			name:       "replace",
+			strategies: map[string]deepmerge.MergeStrategy{"tags": deepmerge.Replace()},
+			dst:        "tags:\n  - a\n  - b\n",
+			src:        "tags:\n  - c\n",
+			expected:   "tags:\n  - c\n",
+		},
+		{
+			name:       "prepend",
+			strategies: map[string]deepmerge.MergeStrategy{"tags": deepmerge.Prepend()},
+			dst:        "tags:\n  - a\n",
+			src:        "tags:\n  - b\n",
+			expected:   "tags:\n  - b\n  - a\n",
+		},
+		{
+			name:       "unique",
+			strategies: map[string]deepmerge.MergeStrategy{"tags": deepmerge.Unique()},
+			dst:        "tags:\n  - a\n  - b\n",
+			src:        "tags:\n  - b\n  - c\n",
+			expected:   "tags:\n  - a\n  - b\n  - c\n",
+		},
+		{
+			name:       "merge-by-key",
+			strategies: map[string]deepmerge.MergeStrategy{"endpoints": deepmerge.MergeByKey("name")},
+			dst: `endpoints:
+  - name: one
+    url: https://example.com
+    interval: 5s`,
+			src: `endpoints:
+  - name: one
+    url: https://example.org
+  - name: two
+    url: https://example.net`,
+			expected: `endpoints:
+  - name: one
+    url: https://example.org
+    interval: 5s
+  - name: two
+    url: https://example.net`,
+		},
+		{
+			name: "merge-by-key-with-nested-unique",
+			strategies: map[string]deepmerge.MergeStrategy{
+				"endpoints":              deepmerge.MergeByKey("name"),
+				"endpoints.*.conditions": deepmerge.Unique(),
+			},
+			dst: `endpoints:
+  - name: one
+    conditions:
+      - "[STATUS] == 200"`,
+			src: `endpoints:
+  - name: one
+    conditions:
+      - "[STATUS] == 200"
+      - "[BODY].status == UP"`,
+			expected: `endpoints:
+  - name: one
+    conditions:
+      - "[STATUS] == 200"
+      - "[BODY].status == UP"`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := deepmerge.Config{Strategies: scenario.strategies}
+			output, err := deepmerge.YAML([]byte(scenario.dst), []byte(scenario.src), config)
+			if err != nil {
+				t.Fatalf("[%s] didn't expect an error, got %v", scenario.name, err)
+			}
+			var outputAsMap, expectedAsMap map[string]interface{}
+			if err := yaml.Unmarshal(output, &outputAsMap); err != nil {
+				t.Fatalf("[%s] failed to unmarshal output: %v", scenario.name, err)
+			}
+			if err := yaml.Unmarshal([]byte(scenario.expected), &expectedAsMap); err != nil {
+				t.Fatalf("[%s] failed to unmarshal expected: %v", scenario.name, err)
+			}
+			formattedOutput, _ := yaml.Marshal(outputAsMap)
+			formattedExpected, _ := yaml.Marshal(expectedAsMap)
+			if string(formattedOutput) != string(formattedExpected) {
+				t.Errorf("[%s] expected:\n%s\n\ngot:\n%s", scenario.name, string(formattedExpected), string(formattedOutput))
+			}
+		})
+	}
+}
+
+// TestYAML_MergeByKeyWithNonComparableKeyValue ensures a MergeByKey field whose value is itself a map or a
+// slice doesn't panic (it used to, indexing a map[interface{}]int with an unhashable key).
+func TestYAML_MergeByKeyWithNonComparableKeyValue(t *testing.T) {
+	config := deepmerge.Config{Strategies: map[string]deepmerge.MergeStrategy{"items": deepmerge.MergeByKey("id")}}
+	dst := `items:
+  - id: {a: 1}
+    value: one`
+	src := `items:
+  - id: {a: 1}
+    value: two`
+	output, err := deepmerge.YAML([]byte(dst), []byte(src), config)
+	if err != nil {
+		t.Fatalf("didn't expect an error, got %v", err)
+	}
+	var result map[string]interface{}
+	if err := yaml.Unmarshal(output, &result); err != nil {
+		t.Fatalf("failed to unmarshal output: %v", err)
+	}
+	items, ok := result["items"].([]interface{})
+	if !ok || len(items) != 1 {
+		t.Fatalf("expected 1 merged item, got %v", result["items"])
+	}
+}
+
+// TestMatchStrategy_DeterministicWithOverlappingPatterns ensures that when two wildcard patterns with the
+// same specificity both match a path, the same one wins on every run, regardless of map iteration order.
+func TestMatchStrategy_DeterministicWithOverlappingPatterns(t *testing.T) {
+	config := deepmerge.Config{Strategies: map[string]deepmerge.MergeStrategy{
+		"a.*.c": deepmerge.Replace(), // lexicographically smaller than "a.b.*", so it should always win
+		"a.b.*": deepmerge.Unique(),
+	}}
+	dst := "a:\n  b:\n    c:\n      - x\n"
+	src := "a:\n  b:\n    c:\n      - y\n"
+	for i := 0; i < 20; i++ {
+		output, err := deepmerge.YAML([]byte(dst), []byte(src), config)
+		if err != nil {
+			t.Fatalf("didn't expect an error, got %v", err)
+		}
+		var result map[string]interface{}
+		if err := yaml.Unmarshal(output, &result); err != nil {
+			t.Fatalf("failed to unmarshal output: %v", err)
+		}
+		a := result["a"].(map[string]interface{})
+		b := a["b"].(map[string]interface{})
+		c := b["c"].([]interface{})
+		if len(c) != 1 || c[0] != "y" {
+			t.Fatalf("expected \"a.*.c\" (Replace) to win deterministically over \"a.b.*\" (Unique), got %v", c)
+		}
+	}
+}